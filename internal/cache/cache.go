@@ -0,0 +1,201 @@
+// Package cache implements an on-disk, content-addressed cache of
+// per-package indexing results. A package whose own source and whose
+// dependencies are unchanged since the last run can be read back from disk
+// instead of being type-checked and walked again, which is where most of
+// scip-go's time goes on a large, mostly-unchanged monorepo.
+package cache
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// FormatVersion identifies the on-disk shape of an Entry. Bump it whenever
+// Entry (or anything gob-encoded inside it) changes shape, so that entries
+// written by an older scip-go binary are treated as a miss rather than
+// misread.
+const FormatVersion = 1
+
+// FileDigest identifies one source file of a package by its content hash.
+type FileDigest struct {
+	Path   string
+	SHA256 string
+}
+
+// DepDigest identifies one dependency of a package by the cache key it was
+// itself indexed under, so that a change to a dependency invalidates this
+// package's entry transitively without re-hashing the dependency's files.
+type DepDigest struct {
+	ImportPath string
+	CacheKey   string
+}
+
+// Key is everything that determines whether a cached package entry may be
+// reused: the package's own content, its dependencies' cache keys, the
+// toolchain that would otherwise re-derive it, and anything computed from
+// outside the package's own dependency graph that the cached entry's
+// contents still depend on (see ImplGraphDigest).
+type Key struct {
+	ImportPath  string
+	Files       []FileDigest
+	Deps        []DepDigest
+	GoVersion   string
+	ToolVersion string
+
+	// ImplGraphDigest digests every interface and concrete type across the
+	// whole loaded package graph, not just this package's own files and
+	// dependencies. A cached entry's Implementation relationships are
+	// computed against the whole graph, so without this field a change to
+	// an unrelated package's interface (most commonly a downstream consumer
+	// declaring a new interface this package's type happens to satisfy)
+	// would never invalidate this package's cache entry.
+	ImplGraphDigest string
+}
+
+// Digest returns the content-addressed cache key for k, suitable for use as
+// a cache file name. Files and Deps don't need to be in a canonical order;
+// Digest sorts copies of them before hashing, leaving k's slices untouched.
+func (k Key) Digest() string {
+	k.Files = append([]FileDigest(nil), k.Files...)
+	k.Deps = append([]DepDigest(nil), k.Deps...)
+
+	sort.Slice(k.Files, func(i, j int) bool { return k.Files[i].Path < k.Files[j].Path })
+	sort.Slice(k.Deps, func(i, j int) bool { return k.Deps[i].ImportPath < k.Deps[j].ImportPath })
+
+	h := sha256.New()
+	if err := json.NewEncoder(h).Encode(k); err != nil {
+		// Key is plain strings and slices thereof; this cannot fail.
+		panic(fmt.Sprintf("cache: failed to encode key: %v", err))
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Entry is the cached indexing output for a single package: one
+// *scip.Document per file it contains, plus the symbol table its
+// dependents need to resolve references into it. Both fields are
+// gob-encoded independently of the caller's in-memory representation, so
+// this package doesn't need to import scip-go's document/lookup/scip types.
+type Entry struct {
+	FormatVersion int
+
+	// gob-encoded []*scip.Document, one per file in the package
+	Documents []byte
+	// gob-encoded *lookup.Package
+	SymbolTable []byte
+}
+
+// Encode gob-encodes value into one of Entry's byte-slice fields.
+func Encode(value any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(value); err != nil {
+		return nil, fmt.Errorf("failed to encode cache value: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Decode gob-decodes one of Entry's byte-slice fields into value.
+func Decode(data []byte, value any) error {
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(value); err != nil {
+		return fmt.Errorf("failed to decode cache value: %w", err)
+	}
+
+	return nil
+}
+
+// Dir returns the on-disk directory scip-go caches package entries under,
+// honoring $XDG_CACHE_HOME (via os.UserCacheDir) when set.
+func Dir() (string, error) {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to determine cache directory: %w", err)
+	}
+
+	return filepath.Join(base, "scip-go"), nil
+}
+
+// Load reads the cache entry for key from disk. It returns (nil, nil) on a
+// cache miss, including when the entry is absent, can't be decoded, or was
+// written by an incompatible FormatVersion — none of those are fatal, since
+// the caller can always fall back to indexing the package from scratch.
+// Any other failure to read an existing entry file is returned as an error.
+func Load(key Key) (*Entry, error) {
+	path, err := entryPath(key)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read cache entry %s: %w", path, err)
+	}
+
+	var entry Entry
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&entry); err != nil {
+		return nil, nil
+	}
+	if entry.FormatVersion != FormatVersion {
+		return nil, nil
+	}
+
+	return &entry, nil
+}
+
+// Store writes entry to disk under key, creating the cache directory if
+// necessary. The write is atomic: it's done via a temp file and rename, so
+// a concurrent Load never observes a partially-written entry.
+func Store(key Key, entry Entry) error {
+	entry.FormatVersion = FormatVersion
+
+	path, err := entryPath(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(entry); err != nil {
+		return fmt.Errorf("failed to encode cache entry: %w", err)
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+
+	return os.Rename(tmp, path)
+}
+
+// HashFile returns the sha256 digest of the file at path, for use as a
+// FileDigest in a Key's Files list.
+func HashFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash %s: %w", path, err)
+	}
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func entryPath(key Key) (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, key.Digest()+".cache"), nil
+}
@@ -3,15 +3,20 @@ package git
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/Masterminds/semver"
+	"golang.org/x/mod/module"
 
 	"github.com/sourcegraph/scip-go/internal/command"
 )
 
 // InferModuleVersion returns the version of the module declared in the given
-// directory. This will be either the work tree commit's tag, or it will be the
-// short revhash of the HEAD commit.
+// directory. This will be either the work tree commit's tag, or a Go module
+// pseudo-version derived from the HEAD commit's committer date and hash, in
+// the same form `go mod` itself records for VCS-derived versions (e.g.
+// v0.0.0-20060102150405-abcdef123456, or vX.Y.(Z+1)-0.… when the nearest
+// ancestor tag is a vX.Y.Z semantic version).
 func InferModuleVersion(dir string) (string, error) {
 	tags, err := command.Run(dir, "git", "tag", "-l", "--points-at", "HEAD")
 	if err != nil {
@@ -25,15 +30,62 @@ func InferModuleVersion(dir string) (string, error) {
 			return tag, nil
 		}
 	}
-	if len(lines) > 0 {
+	if len(lines) > 0 && lines[0] != "" {
 		// None of the tags look like a version, but return one of them anyway.
 		return lines[0], nil
 	}
 
-	commit, err := command.Run(dir, "git", "rev-parse", "HEAD")
+	return pseudoVersion(dir)
+}
+
+// pseudoVersion builds a Go module pseudo-version for the HEAD commit,
+// following golang.org/x/mod/module.PseudoVersion: it anchors the version on
+// the nearest reachable tag (if any), bumping it per semver rules, and
+// appends HEAD's committer timestamp (UTC) and short hash. A base tag that
+// isn't a valid Go semantic version (e.g. "1.2.3" without the "v" prefix) is
+// ignored by module.PseudoVersion, so the result is anchored at v0.0.0.
+func pseudoVersion(dir string) (string, error) {
+	rawDate, err := command.Run(dir, "git", "show", "-s", "--format=%cI", "HEAD")
+	if err != nil {
+		return "", fmt.Errorf("failed to get commit date for HEAD: %v\n%s", err, rawDate)
+	}
+	commitTime, err := time.Parse(time.RFC3339, strings.TrimSpace(rawDate))
+	if err != nil {
+		return "", fmt.Errorf("failed to parse commit date %q: %v", rawDate, err)
+	}
+
+	rawHash, err := command.Run(dir, "git", "rev-parse", "HEAD")
+	if err != nil {
+		return "", fmt.Errorf("failed to get current commit: %v\n%s", err, rawHash)
+	}
+	hash := strings.TrimSpace(rawHash)
+	if len(hash) < 12 {
+		return "", fmt.Errorf("commit hash %q is shorter than 12 characters", hash)
+	}
+
+	base, err := baseVersion(dir)
 	if err != nil {
-		return "", fmt.Errorf("failed to get current commit: %v\n%s", err, commit)
+		return "", err
+	}
+
+	return module.PseudoVersion("", base, commitTime.UTC(), hash[:12]), nil
+}
+
+// baseVersion returns the most recent tag reachable from HEAD, for use as
+// the base of a pseudo-version. It returns "" if the work tree has no
+// reachable tags, which is not an error.
+func baseVersion(dir string) (string, error) {
+	tag, err := command.Run(dir, "git", "describe", "--tags", "--abbrev=0")
+	if err != nil {
+		// No reachable tag; the pseudo-version will be anchored at v0.0.0.
+		return "", nil
+	}
+
+	tag = strings.TrimSpace(tag)
+	if _, err := semver.NewVersion(tag); err != nil {
+		// Not a version-shaped tag; don't use it as a base.
+		return "", nil
 	}
 
-	return commit[:12], nil
+	return tag, nil
 }
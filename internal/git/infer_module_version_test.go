@@ -0,0 +1,106 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"regexp"
+	"testing"
+)
+
+// newTestRepo creates a throwaway git repository with a single commit and
+// returns its directory.
+func newTestRepo(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	runGit(t, dir, "init", "-q")
+	runGit(t, dir, "config", "user.name", "test")
+	runGit(t, dir, "config", "user.email", "test@example.com")
+
+	if err := os.WriteFile(dir+"/file.txt", []byte("content"), 0o644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	runGit(t, dir, "add", "file.txt")
+	runGit(t, dir, "commit", "-q", "-m", "initial commit")
+
+	return dir
+}
+
+func runGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+
+	return string(out)
+}
+
+func TestBaseVersion(t *testing.T) {
+	tests := []struct {
+		name string
+		tag  string
+		want string
+	}{
+		{name: "no tag", tag: "", want: ""},
+		{name: "v-prefixed semver tag", tag: "v1.2.3", want: "v1.2.3"},
+		{name: "non-v-prefixed semver tag", tag: "1.2.3", want: "1.2.3"},
+		{name: "non-semver tag", tag: "release-candidate", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := newTestRepo(t)
+			if tt.tag != "" {
+				runGit(t, dir, "tag", tt.tag)
+			}
+
+			got, err := baseVersion(dir)
+			if err != nil {
+				t.Fatalf("baseVersion() returned error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("baseVersion() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+var pseudoVersionPattern = regexp.MustCompile(`^v0\.0\.0-\d{14}-[0-9a-f]{12}$`)
+
+func TestPseudoVersion_NoBaseTag(t *testing.T) {
+	dir := newTestRepo(t)
+
+	got, err := pseudoVersion(dir)
+	if err != nil {
+		t.Fatalf("pseudoVersion() returned error: %v", err)
+	}
+	if !pseudoVersionPattern.MatchString(got) {
+		t.Errorf("pseudoVersion() = %q, want a match for %s", got, pseudoVersionPattern)
+	}
+}
+
+var pseudoVersionWithBasePattern = regexp.MustCompile(`^v1\.2\.4-0\.\d{14}-[0-9a-f]{12}$`)
+
+func TestPseudoVersion_WithBaseTag(t *testing.T) {
+	dir := newTestRepo(t)
+	runGit(t, dir, "tag", "v1.2.3")
+
+	// A second commit past the tag, so HEAD isn't the tagged commit itself.
+	if err := os.WriteFile(dir+"/file2.txt", []byte("more"), 0o644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	runGit(t, dir, "add", "file2.txt")
+	runGit(t, dir, "commit", "-q", "-m", "second commit")
+
+	got, err := pseudoVersion(dir)
+	if err != nil {
+		t.Fatalf("pseudoVersion() returned error: %v", err)
+	}
+	if !pseudoVersionWithBasePattern.MatchString(got) {
+		t.Errorf("pseudoVersion() = %q, want a match for %s", got, pseudoVersionWithBasePattern)
+	}
+}
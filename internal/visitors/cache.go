@@ -0,0 +1,29 @@
+package visitors
+
+import (
+	"github.com/sourcegraph/scip-go/internal/cache"
+	"github.com/sourcegraph/scip/bindings/go/scip"
+)
+
+// EncodeCacheEntry serializes a package's SCIP documents into a cache.Entry,
+// for storage by the indexing pipeline once every file in the package has
+// been visited.
+func EncodeCacheEntry(docs []*scip.Document) (cache.Entry, error) {
+	encoded, err := cache.Encode(docs)
+	if err != nil {
+		return cache.Entry{}, err
+	}
+
+	return cache.Entry{Documents: encoded}, nil
+}
+
+// DecodeCacheEntry deserializes the SCIP documents out of a cache.Entry
+// previously produced by EncodeCacheEntry.
+func DecodeCacheEntry(entry cache.Entry) ([]*scip.Document, error) {
+	var docs []*scip.Document
+	if err := cache.Decode(entry.Documents, &docs); err != nil {
+		return nil, err
+	}
+
+	return docs, nil
+}
@@ -0,0 +1,192 @@
+package visitors
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"go/types"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/sourcegraph/scip-go/internal/lookup"
+	"github.com/sourcegraph/scip/bindings/go/scip"
+	"golang.org/x/tools/go/packages"
+)
+
+// ImplementationRelationships maps a symbol to the Implementation
+// relationships that should be recorded on its SymbolInformation.
+type ImplementationRelationships map[string][]*scip.Relationship
+
+// candidateTypes walks every package in the loaded package graph and
+// collects the named types that could plausibly participate in an
+// Implementation relationship: interfaces with at least one method, and
+// concrete types whose method set (including methods promoted from an
+// embedded field) is non-empty on either the value or pointer type.
+func candidateTypes(pkgs []*packages.Package) (concreteTypes, interfaces []*types.Named) {
+	packages.Visit(pkgs, nil, func(pkg *packages.Package) {
+		if pkg.Types == nil {
+			return
+		}
+
+		scope := pkg.Types.Scope()
+		for _, name := range scope.Names() {
+			obj, ok := scope.Lookup(name).(*types.TypeName)
+			if !ok {
+				continue
+			}
+
+			named, ok := obj.Type().(*types.Named)
+			if !ok {
+				continue
+			}
+
+			if iface, ok := named.Underlying().(*types.Interface); ok {
+				// NumMethods() on *types.Named only counts explicitly
+				// declared methods, which is always 0 for an interface
+				// (interfaces can't have a receiver) — the interface's
+				// methods live on its underlying *types.Interface instead.
+				if iface.NumMethods() > 0 {
+					interfaces = append(interfaces, named)
+				}
+			} else if types.NewMethodSet(named).Len() > 0 || types.NewMethodSet(types.NewPointer(named)).Len() > 0 {
+				// Named.NumMethods() only counts methods declared directly
+				// on named, not ones promoted from an embedded field, so a
+				// type that satisfies an interface purely through embedding
+				// would otherwise be dropped here before types.Implements
+				// ever runs. The method set accounts for promotion.
+				concreteTypes = append(concreteTypes, named)
+			}
+		}
+	})
+
+	return concreteTypes, interfaces
+}
+
+// ImplementationGraphDigest summarizes every interface and concrete type
+// ComputeImplementationRelationships would consider across pkgs, as a single
+// content hash. Two calls produce the same digest if and only if the set of
+// candidate types and their method sets are identical, which is exactly the
+// input ComputeImplementationRelationships' output depends on.
+//
+// Callers that cache per-package results derived from
+// ComputeImplementationRelationships need this: relationships are computed
+// across the whole package graph, not just a package's own files and
+// dependencies, so a cache key built only from those would go stale when an
+// unrelated package's interface or method set changes.
+func ImplementationGraphDigest(pkgs []*packages.Package) string {
+	concreteTypes, interfaces := candidateTypes(pkgs)
+
+	entries := make([]string, 0, len(concreteTypes)+len(interfaces))
+	for _, named := range concreteTypes {
+		entries = append(entries, typeDigestEntry(named))
+	}
+	for _, named := range interfaces {
+		entries = append(entries, typeDigestEntry(named))
+	}
+	sort.Strings(entries)
+
+	h := sha256.New()
+	for _, entry := range entries {
+		io.WriteString(h, entry)
+		io.WriteString(h, "\n")
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// typeDigestEntry identifies named by its fully-qualified name and the
+// names and signatures of every method in its (promotion-inclusive) method
+// set, so that renaming, adding, removing, or retyping a method changes the
+// entry.
+func typeDigestEntry(named *types.Named) string {
+	obj := named.Obj()
+
+	// A concrete type's pointer method set also holds its pointer-receiver
+	// methods, which ComputeImplementationRelationships checks against.
+	methodSet := types.NewMethodSet(named)
+	if !types.IsInterface(named) {
+		methodSet = types.NewMethodSet(types.NewPointer(named))
+	}
+	methods := make([]string, 0, methodSet.Len())
+	for i := 0; i < methodSet.Len(); i++ {
+		method := methodSet.At(i).Obj()
+		methods = append(methods, method.Name()+method.Type().String())
+	}
+	sort.Strings(methods)
+
+	return fmt.Sprintf("%s.%s|%s", obj.Pkg().Path(), obj.Name(), strings.Join(methods, ","))
+}
+
+// ComputeImplementationRelationships walks every package in the loaded
+// package graph, and for each named type with methods, checks it against
+// every interface also found in the graph. Concrete types (and pointers to
+// them) that satisfy an interface get an IsImplementation relationship from
+// their type symbol to the interface's type symbol, and from each matching
+// method's symbol to the corresponding interface method's symbol.
+//
+// This must run after pkgSymbols/globalSymbols have been built for every
+// package, since it needs to resolve symbols across package boundaries.
+func ComputeImplementationRelationships(
+	pkgs []*packages.Package,
+	globalSymbols *lookup.Global,
+) ImplementationRelationships {
+	concreteTypes, interfaces := candidateTypes(pkgs)
+
+	relationships := ImplementationRelationships{}
+
+	for _, concrete := range concreteTypes {
+		concreteSymbol, ok, err := globalSymbols.GetSymbolOfObject(concrete.Obj())
+		if err != nil || !ok {
+			continue
+		}
+
+		for _, iface := range interfaces {
+			ifaceType, ok := iface.Underlying().(*types.Interface)
+			if !ok {
+				continue
+			}
+
+			if !types.Implements(concrete, ifaceType) && !types.Implements(types.NewPointer(concrete), ifaceType) {
+				continue
+			}
+
+			ifaceSymbol, ok, err := globalSymbols.GetSymbolOfObject(iface.Obj())
+			if err != nil || !ok {
+				continue
+			}
+
+			relationships[concreteSymbol.Symbol] = append(relationships[concreteSymbol.Symbol], &scip.Relationship{
+				Symbol:           ifaceSymbol.Symbol,
+				IsImplementation: true,
+			})
+
+			for i := 0; i < ifaceType.NumMethods(); i++ {
+				ifaceMethod := ifaceType.Method(i)
+
+				concreteMethod, _, _ := types.LookupFieldOrMethod(concrete, true, concrete.Obj().Pkg(), ifaceMethod.Name())
+				concreteFunc, ok := concreteMethod.(*types.Func)
+				if !ok {
+					continue
+				}
+
+				concreteMethodSymbol, ok, err := globalSymbols.GetSymbolOfObject(concreteFunc)
+				if err != nil || !ok {
+					continue
+				}
+
+				ifaceMethodSymbol, ok, err := globalSymbols.GetSymbolOfObject(ifaceMethod)
+				if err != nil || !ok {
+					continue
+				}
+
+				relationships[concreteMethodSymbol.Symbol] = append(relationships[concreteMethodSymbol.Symbol], &scip.Relationship{
+					Symbol:           ifaceMethodSymbol.Symbol,
+					IsImplementation: true,
+				})
+			}
+		}
+	}
+
+	return relationships
+}
@@ -0,0 +1,113 @@
+package visitors
+
+import (
+	"fmt"
+	"go/ast"
+	"runtime"
+
+	"github.com/sourcegraph/scip-go/internal/cache"
+	"github.com/sourcegraph/scip-go/internal/document"
+	"github.com/sourcegraph/scip-go/internal/loader"
+	"github.com/sourcegraph/scip-go/internal/lookup"
+	"github.com/sourcegraph/scip/bindings/go/scip"
+	"golang.org/x/tools/go/packages"
+)
+
+// ToolVersion is mixed into cache keys so that entries written by an older
+// scip-go build are invalidated rather than misread. Override it at link
+// time, e.g. -ldflags "-X .../visitors.ToolVersion=$(git describe)".
+var ToolVersion = "dev"
+
+// VisitPackage produces the SCIP documents for every file in pkg. It
+// consults the on-disk cache first, keyed on pkg's own files and its
+// dependencies' cache keys, and on a hit returns the cached documents
+// without walking any ASTs. On a miss, it computes implementation
+// relationships once across allPkgs, walks each of pkg's files with a
+// fileVisitor, and writes the result back to the cache before returning.
+//
+// docsByFile, pkgLookup, pkgSymbols and globalSymbols are whatever the
+// caller has already built for pkg in order to construct a fileVisitor
+// directly; allPkgs is the full loaded package graph, needed to compute
+// implementation relationships across package boundaries.
+//
+// This package tree has no cmd/ or indexer package of its own yet — the
+// top-level driver that currently builds pkgSymbols/globalSymbols per
+// package and calls NewFileVisitor directly lives outside this snapshot.
+// VisitPackage is the entry point that driver should call instead, once it
+// exists in this tree; there is nothing else here for it to be wired into.
+func VisitPackage(
+	allPkgs []*packages.Package,
+	pkg *packages.Package,
+	files []*ast.File,
+	docsByFile map[*ast.File]*document.Document,
+	pkgLookup loader.PackageLookup,
+	pkgSymbols *lookup.Package,
+	globalSymbols *lookup.Global,
+) ([]*scip.Document, error) {
+	// Computed unconditionally, even on what might be a cache hit: it's also
+	// folded into the cache key below, since ComputeImplementationRelationships
+	// considers the whole package graph, not just pkg's own files and deps.
+	implGraphDigest := ImplementationGraphDigest(allPkgs)
+
+	key, keyErr := packageCacheKey(pkg, implGraphDigest)
+	if keyErr == nil {
+		if entry, err := cache.Load(key); err == nil && entry != nil {
+			if docs, err := DecodeCacheEntry(*entry); err == nil {
+				return docs, nil
+			}
+		}
+	}
+
+	implementations := ComputeImplementationRelationships(allPkgs, globalSymbols)
+
+	docs := make([]*scip.Document, 0, len(files))
+	for _, file := range files {
+		visitor := NewFileVisitor(docsByFile[file], pkg, file, pkgLookup, pkgSymbols, globalSymbols, implementations)
+		ast.Walk(visitor, file)
+		docs = append(docs, visitor.ToScipDocument())
+	}
+
+	if keyErr == nil {
+		if entry, err := EncodeCacheEntry(docs); err == nil {
+			_ = cache.Store(key, entry)
+		}
+	}
+
+	return docs, nil
+}
+
+// packageCacheKey builds the cache.Key for pkg: its own Go files hashed by
+// content, its direct dependencies identified by their own cache keys (which
+// recursively account for their dependencies in turn), and implGraphDigest,
+// the same for every package in this run, so that a change anywhere in the
+// program's interface/method-set graph invalidates every package's entry.
+func packageCacheKey(pkg *packages.Package, implGraphDigest string) (cache.Key, error) {
+	files := make([]cache.FileDigest, 0, len(pkg.GoFiles))
+	for _, file := range pkg.GoFiles {
+		sum, err := cache.HashFile(file)
+		if err != nil {
+			return cache.Key{}, fmt.Errorf("failed to build cache key for %s: %w", pkg.PkgPath, err)
+		}
+
+		files = append(files, cache.FileDigest{Path: file, SHA256: sum})
+	}
+
+	deps := make([]cache.DepDigest, 0, len(pkg.Imports))
+	for importPath, dep := range pkg.Imports {
+		depKey, err := packageCacheKey(dep, implGraphDigest)
+		if err != nil {
+			return cache.Key{}, err
+		}
+
+		deps = append(deps, cache.DepDigest{ImportPath: importPath, CacheKey: depKey.Digest()})
+	}
+
+	return cache.Key{
+		ImportPath:      pkg.PkgPath,
+		Files:           files,
+		Deps:            deps,
+		GoVersion:       runtime.Version(),
+		ToolVersion:     ToolVersion,
+		ImplGraphDigest: implGraphDigest,
+	}, nil
+}
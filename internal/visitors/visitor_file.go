@@ -21,6 +21,7 @@ import (
 const (
 	symbolDefinition = int32(scip.SymbolRole_Definition)
 	symbolReference  = int32(scip.SymbolRole_ReadAccess)
+	symbolWrite      = int32(scip.SymbolRole_WriteAccess)
 )
 
 func NewFileVisitor(
@@ -30,11 +31,21 @@ func NewFileVisitor(
 	pkgLookup loader.PackageLookup,
 	pkgSymbols *lookup.Package,
 	globalSymbols *lookup.Global,
+	implementations ImplementationRelationships,
 ) *fileVisitor {
-	caseClauses := map[token.Pos]types.Object{}
+	// A single "implicit position -> object" table, covering every implicit
+	// kind that go/types hangs a synthesized *types.Object off of a
+	// statement rather than an *ast.Ident: type-switch case clauses. Range
+	// clause variables aren't implicit — go/types records them as ordinary
+	// Defs/Uses entries, already handled by the *ast.Ident branch below, so
+	// there's nothing to add here for them. Dot imports are also implicit,
+	// but they're handled in the *ast.ImportSpec branch below instead,
+	// since they need to feed the pkgNameOverride/dotImportPackages tables
+	// rather than the identifier-matching table.
+	implicits := map[token.Pos]types.Object{}
 	for implicit, obj := range pkg.TypesInfo.Implicits {
 		if _, ok := implicit.(*ast.CaseClause); ok {
-			caseClauses[obj.Pos()] = obj
+			implicits[obj.Pos()] = obj
 		}
 	}
 
@@ -44,24 +55,68 @@ func NewFileVisitor(
 	}
 
 	return &fileVisitor{
-		doc:           doc,
-		pkg:           pkg,
-		file:          file,
-		pkgLookup:     pkgLookup,
-		locals:        map[token.Pos]lookup.Local{},
-		pkgSymbols:    pkgSymbols,
-		globalSymbols: globalSymbols,
-		occurrences:   occurrences,
+		doc:             doc,
+		pkg:             pkg,
+		file:            file,
+		pkgLookup:       pkgLookup,
+		locals:          map[token.Pos]lookup.Local{},
+		pkgSymbols:      pkgSymbols,
+		globalSymbols:   globalSymbols,
+		implementations: implementations,
+		occurrences:     occurrences,
 		overrides: struct {
-			caseClauses     map[token.Pos]types.Object
-			pkgNameOverride map[newtypes.PackageID]string
+			implicits         map[token.Pos]types.Object
+			pkgNameOverride   map[newtypes.PackageID]string
+			writeAccess       map[token.Pos]int32
+			dotImportPackages map[*types.Package]string
 		}{
-			caseClauses:     caseClauses,
-			pkgNameOverride: map[newtypes.PackageID]string{},
+			implicits:         implicits,
+			pkgNameOverride:   map[newtypes.PackageID]string{},
+			writeAccess:       writeAccessPositions(file),
+			dotImportPackages: map[*types.Package]string{},
 		},
 	}
 }
 
+// writeAccessPositions finds every identifier position in file that denotes
+// a write to an existing variable, and maps it to the symbol roles that
+// occurrence should carry. Plain assignment (`x = y`, `x := y`) and the
+// operand of `&x` are pure writes; compound assignment (`x += y`) and
+// `x++`/`x--` both read and write, so they carry both roles.
+func writeAccessPositions(file *ast.File) map[token.Pos]int32 {
+	positions := map[token.Pos]int32{}
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.AssignStmt:
+			role := symbolWrite
+			if node.Tok != token.ASSIGN && node.Tok != token.DEFINE {
+				// Compound assignment (+=, -=, ...) reads the old value too.
+				role |= symbolReference
+			}
+			for _, lhs := range node.Lhs {
+				if ident, ok := lhs.(*ast.Ident); ok {
+					positions[ident.Pos()] = role
+				}
+			}
+		case *ast.IncDecStmt:
+			if ident, ok := node.X.(*ast.Ident); ok {
+				positions[ident.Pos()] = symbolWrite | symbolReference
+			}
+		case *ast.UnaryExpr:
+			if node.Op == token.AND {
+				if ident, ok := node.X.(*ast.Ident); ok {
+					positions[ident.Pos()] = symbolWrite
+				}
+			}
+		}
+
+		return true
+	})
+
+	return positions
+}
+
 // fileVisitor visits an entire file, but it must be called
 // after StructVisitor.
 //
@@ -86,17 +141,31 @@ type fileVisitor struct {
 	// field definition position to symbol for the entire compliation
 	globalSymbols *lookup.Global
 
+	// Implementation relationships (interface <-> concrete type/method)
+	// keyed by symbol, computed once for the whole package graph
+	implementations ImplementationRelationships
+
 	// occurrences in this file
 	occurrences []*scip.Occurrence
 
 	// Overriding Definition Behvaior:
 	overrides struct {
-		// Case clauses have to map particular positions to different types
-		caseClauses map[token.Pos]types.Object
+		// Implicit objects (type-switch case clauses) have to map
+		// particular positions to different types
+		implicits map[token.Pos]types.Object
 
 		// maps tokens for package declaration to a local var,
 		// if ImportSpec.Name is not nil. Otherwise, just use package directly
 		pkgNameOverride map[newtypes.PackageID]string
+
+		// maps identifier positions that are write targets (assignment LHS,
+		// ++/--, &x) to the symbol roles their occurrence should carry
+		writeAccess map[token.Pos]int32
+
+		// maps the *types.Package of a dot-imported package to the local
+		// symbol created for its (unnamed) import, so unqualified
+		// identifiers resolving into it can also reference the import
+		dotImportPackages map[*types.Package]string
 	}
 }
 
@@ -142,6 +211,20 @@ func (v *fileVisitor) Visit(n ast.Node) ast.Visitor {
 			// Save package name override, so that we use the new local symbol
 			// within this file
 			v.overrides.pkgNameOverride[newtypes.GetID(importedPackage)] = symName
+		} else if node.Name != nil && node.Name.Name == "." {
+			// Dot import: go/types records the implicit *types.PkgName
+			// against the ImportSpec itself, since there's no identifier
+			// in source to attach it to.
+			if pkgName, ok := v.pkg.TypesInfo.Implicits[node].(*types.PkgName); ok {
+				symName := v.createNewLocalSymbol(node.Name.Pos(), pkgName)
+				rangeFromName := symbols.RangeFromName(
+					v.pkg.Fset.Position(node.Name.Pos()), node.Name.Name, false)
+				v.NewDefinition(symName, rangeFromName)
+
+				// Every unqualified identifier resolving into this package
+				// should also reference the import itself.
+				v.overrides.dotImportPackages[importedPackage.Types] = symName
+			}
 		}
 
 		position := v.pkg.Fset.Position(node.Path.Pos())
@@ -205,7 +288,7 @@ func (v *fileVisitor) Visit(n ast.Node) ast.Visitor {
 		position := v.pkg.Fset.Position(pos)
 
 		// Short circuit on case clauses
-		if obj, ok := v.overrides.caseClauses[node.Pos()]; ok {
+		if obj, ok := v.overrides.implicits[node.Pos()]; ok {
 			symName := v.createNewLocalSymbol(obj.Pos(), obj)
 			v.NewDefinition(symName, scipRange(position, obj))
 			return nil
@@ -216,6 +299,15 @@ func (v *fileVisitor) Visit(n ast.Node) ast.Visitor {
 		// Emit Definition
 		def := info.Defs[node]
 		if def != nil {
+			if field, ok := def.(*types.Var); ok && field.Anonymous() {
+				// The identifier for an embedded field both declares the
+				// implicit field and references the embedded type; emit
+				// both, rather than letting the field definition get
+				// conflated with the type it embeds.
+				v.emitEmbeddedFieldOccurrence(node, position, field, true)
+				return nil
+			}
+
 			var symName string
 			if pkgSymbols, ok := v.pkgSymbols.GetSymbol(def.Pos()); ok {
 				symName = pkgSymbols
@@ -231,6 +323,11 @@ func (v *fileVisitor) Visit(n ast.Node) ast.Visitor {
 		// Emit Reference
 		ref := info.Uses[node]
 		if ref != nil {
+			if field, ok := ref.(*types.Var); ok && field.Anonymous() {
+				v.emitEmbeddedFieldOccurrence(node, position, field, false)
+				return nil
+			}
+
 			var (
 				symbol       string
 				overrideType types.Type
@@ -239,7 +336,7 @@ func (v *fileVisitor) Visit(n ast.Node) ast.Visitor {
 			if localSymbol, ok := v.locals[ref.Pos()]; ok {
 				symbol = localSymbol.Symbol
 
-				if _, ok := v.overrides.caseClauses[ref.Pos()]; ok {
+				if _, ok := v.overrides.implicits[ref.Pos()]; ok {
 					overrideType = v.pkg.TypesInfo.TypeOf(node)
 				}
 			} else {
@@ -276,7 +373,21 @@ func (v *fileVisitor) Visit(n ast.Node) ast.Visitor {
 				symbol = symInfo.Symbol
 			}
 
-			v.AppendSymbolReference(symbol, scipRange(position, ref), overrideType)
+			role := symbolReference
+			if writeRole, ok := v.overrides.writeAccess[node.Pos()]; ok {
+				role = writeRole
+			}
+
+			v.appendSymbolReferenceWithRole(symbol, scipRange(position, ref), overrideType, role)
+
+			// Unqualified identifiers bound by a dot import also reference
+			// the import itself, same as the PkgName occurrence emitted for
+			// a qualified `pkg.Foo` access.
+			if ref.Pkg() != nil && ref.Parent() == ref.Pkg().Scope() {
+				if dotImportSymbol, ok := v.overrides.dotImportPackages[ref.Pkg()]; ok {
+					v.AppendSymbolReference(dotImportSymbol, scipRange(position, ref), nil)
+				}
+			}
 		}
 
 		if def == nil && ref == nil {
@@ -292,6 +403,70 @@ func (v *fileVisitor) Visit(n ast.Node) ast.Visitor {
 	return v
 }
 
+// emitEmbeddedFieldOccurrence handles a selector identifier that denotes an
+// embedded field, e.g. `x.Inner` where Inner was embedded anonymously. Such
+// an identifier simultaneously names the implicit field and the type being
+// embedded, so it gets two occurrences at the same range: one against a
+// field symbol synthesized for the embedding, and one Reference against the
+// embedded type's own symbol. isDefinition is true at the field's embedding
+// site (`type Outer struct { Inner }`) and false at every later access
+// (`x.Inner`).
+func (v *fileVisitor) emitEmbeddedFieldOccurrence(node *ast.Ident, position token.Position, field *types.Var, isDefinition bool) {
+	rng := scipRange(position, field)
+
+	// The embedding site and every later access are almost always in
+	// different files, so the field's symbol can't be looked up (or minted)
+	// in v.locals, which is scoped to this single document. StructVisitor
+	// runs before fileVisitor and registers the field in pkgSymbols, so
+	// resolve through the same pkgSymbols/globalSymbols chain used for
+	// ordinary defs/refs above, falling back to a local only if neither has
+	// it (e.g. a locally-scoped anonymous struct field).
+	var fieldSymbol string
+	if symbol, ok := v.pkgSymbols.GetSymbol(field.Pos()); ok {
+		fieldSymbol = symbol
+	} else if symbol, ok := v.globalSymbols.GetSymbol(v.pkg, field.Pos()); ok {
+		fieldSymbol = symbol
+	} else if local, ok := v.locals[field.Pos()]; ok {
+		fieldSymbol = local.Symbol
+	} else {
+		fieldSymbol = v.createNewLocalSymbol(field.Pos(), field)
+	}
+
+	if isDefinition {
+		v.NewDefinition(fieldSymbol, rng)
+	} else {
+		role := symbolReference
+		if writeRole, ok := v.overrides.writeAccess[node.Pos()]; ok {
+			role = writeRole
+		}
+
+		v.appendSymbolReferenceWithRole(fieldSymbol, rng, nil, role)
+	}
+
+	named := namedTypeOf(field.Type())
+	if named == nil {
+		return
+	}
+
+	if typeSymbol, ok, err := v.globalSymbols.GetSymbolOfObject(named.Obj()); err == nil && ok {
+		v.AppendSymbolReference(typeSymbol.Symbol, rng, nil)
+	} else if typeSymbol, ok := v.pkgSymbols.GetSymbol(named.Obj().Pos()); ok {
+		v.AppendSymbolReference(typeSymbol, rng, nil)
+	}
+}
+
+// namedTypeOf unwraps a single pointer indirection to find the *types.Named
+// underlying an embedded field's type, covering both `Inner` and `*Inner`
+// embeddings.
+func namedTypeOf(t types.Type) *types.Named {
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+
+	named, _ := t.(*types.Named)
+	return named
+}
+
 func (v *fileVisitor) emitImportReference(
 	globalSymbols *lookup.Global,
 	position token.Position,
@@ -323,6 +498,13 @@ func (v *fileVisitor) NewDefinition(symbol string, rng []int32) {
 }
 
 func (v *fileVisitor) AppendSymbolReference(symbol string, rng []int32, overrideType types.Type) {
+	v.appendSymbolReferenceWithRole(symbol, rng, overrideType, symbolReference)
+}
+
+// appendSymbolReferenceWithRole is like AppendSymbolReference, but lets the
+// caller override the SymbolRoles bitmask, e.g. to mark an occurrence as a
+// write (or read-modify-write) instead of a plain read.
+func (v *fileVisitor) appendSymbolReferenceWithRole(symbol string, rng []int32, overrideType types.Type, role int32) {
 	var documentation []string = nil
 	if overrideType != nil {
 		tyString := overrideType.String()
@@ -334,7 +516,7 @@ func (v *fileVisitor) AppendSymbolReference(symbol string, rng []int32, override
 	v.occurrences = append(v.occurrences, &scip.Occurrence{
 		Range:                 rng,
 		Symbol:                symbol,
-		SymbolRoles:           symbolReference,
+		SymbolRoles:           role,
 		OverrideDocumentation: documentation,
 	})
 }
@@ -346,6 +528,12 @@ func (v *fileVisitor) ToScipDocument() *scip.Document {
 	}
 
 	documentSymbols := v.pkgSymbols.SymbolsForFile(documentFile)
+	for _, symbolInfo := range documentSymbols {
+		if rels, ok := v.implementations[symbolInfo.Symbol]; ok {
+			symbolInfo.Relationships = append(symbolInfo.Relationships, rels...)
+		}
+	}
+
 	for _, local := range v.locals {
 		symbolInfo := &scip.SymbolInformation{
 			Symbol: local.Symbol,
@@ -0,0 +1,114 @@
+package visitors
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"testing"
+)
+
+// parseIdentPos parses src (a complete file) and returns the position of the
+// nth (0-indexed) identifier named name.
+func parseIdentPos(t *testing.T, src, name string, occurrence int) (*ast.File, token.Pos) {
+	t.Helper()
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "test.go", src, 0)
+	if err != nil {
+		t.Fatalf("failed to parse source: %v", err)
+	}
+
+	count := 0
+	var pos token.Pos
+	ast.Inspect(file, func(n ast.Node) bool {
+		if ident, ok := n.(*ast.Ident); ok && ident.Name == name {
+			if count == occurrence {
+				pos = ident.Pos()
+			}
+			count++
+		}
+		return true
+	})
+	if pos == token.NoPos {
+		t.Fatalf("identifier %q (occurrence %d) not found in source", name, occurrence)
+	}
+
+	return file, pos
+}
+
+func TestWriteAccessPositions(t *testing.T) {
+	tests := []struct {
+		name       string
+		src        string
+		ident      string
+		occurrence int
+		want       int32
+		wantAbsent bool
+	}{
+		{
+			name:       "plain assignment writes the LHS",
+			src:        "package p\nfunc f() {\n\tvar x, y int\n\tx = y\n}\n",
+			ident:      "x",
+			occurrence: 1,
+			want:       symbolWrite,
+		},
+		{
+			name:       "plain assignment does not tag the RHS",
+			src:        "package p\nfunc f() {\n\tvar x, y int\n\tx = y\n}\n",
+			ident:      "y",
+			occurrence: 1,
+			wantAbsent: true,
+		},
+		{
+			name:       "short variable declaration writes the LHS",
+			src:        "package p\nfunc f() {\n\tvar y int\n\tx := y\n}\n",
+			ident:      "x",
+			occurrence: 0,
+			want:       symbolWrite,
+		},
+		{
+			name:       "compound assignment reads and writes the LHS",
+			src:        "package p\nfunc f() {\n\tvar x, y int\n\tx += y\n}\n",
+			ident:      "x",
+			occurrence: 1,
+			want:       symbolWrite | symbolReference,
+		},
+		{
+			name:       "IncDecStmt reads and writes the operand",
+			src:        "package p\nfunc f() {\n\tvar x int\n\tx++\n}\n",
+			ident:      "x",
+			occurrence: 1,
+			want:       symbolWrite | symbolReference,
+		},
+		{
+			name:       "address-of only writes the operand",
+			src:        "package p\nfunc f() {\n\tvar x int\n\t_ = &x\n}\n",
+			ident:      "x",
+			occurrence: 1,
+			want:       symbolWrite,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, pos := parseIdentPos(t, tt.src, tt.ident, tt.occurrence)
+
+			positions := writeAccessPositions(file)
+			got, ok := positions[pos]
+
+			if tt.wantAbsent {
+				if ok {
+					t.Errorf("writeAccessPositions()[pos] = %d, want absent", got)
+				}
+				return
+			}
+
+			if !ok {
+				t.Fatalf("writeAccessPositions() has no entry for %q, want %d", tt.ident, tt.want)
+			}
+			if got != tt.want {
+				t.Errorf("writeAccessPositions()[pos] = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}